@@ -0,0 +1,78 @@
+package slugger
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+)
+
+// Config holds all settings of a Slugger so that it can be stored, e.g. as JSON, and recreated
+// with NewFromConfig. Emoji locales registered with RegisterEmojiLocale are not part of it.
+type Config struct {
+	Separator     string            `json:"separator"`
+	Substitutions map[string]string `json:"substitutions,omitempty"`
+	WithEmoji     bool              `json:"with_emoji,omitempty"`
+	EmojiLocale   string            `json:"emoji_locale,omitempty"`
+	MaxLength     int               `json:"max_length,omitempty"`
+	Transliterate []string          `json:"transliterate,omitempty"`
+	PreserveCase  bool              `json:"preserve_case,omitempty"`
+	Fallback      string            `json:"fallback,omitempty"`
+	KeepChars     string            `json:"keep_chars,omitempty"`
+	Stopwords     []string          `json:"stopwords,omitempty"`
+}
+
+// configJSON has the fields of Config without its methods.
+type configJSON Config
+
+// MarshalJSON implements json.Marshaler.
+func (c Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(configJSON(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A missing separator defaults to "-" like in New.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	config := configJSON{Separator: "-"}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	*c = Config(config)
+
+	return nil
+}
+
+// Config returns a copy of the settings of the Slugger.
+func (slugger *Slugger) Config() Config {
+	slugger.mu.RLock()
+	defer slugger.mu.RUnlock()
+
+	return Config{
+		Separator:     slugger.Separator,
+		Substitutions: maps.Clone(slugger.Substitutions),
+		WithEmoji:     slugger.WithEmoji,
+		EmojiLocale:   slugger.EmojiLocale,
+		MaxLength:     slugger.MaxLength,
+		Transliterate: slices.Clone(slugger.Transliterate),
+		PreserveCase:  slugger.PreserveCase,
+		Fallback:      slugger.Fallback,
+		KeepChars:     slugger.KeepChars,
+		Stopwords:     slices.Clone(slugger.Stopwords),
+	}
+}
+
+// NewFromConfig creates a new Slugger with the given settings. NewFromConfig(sl.Config())
+// generates the same slugs as `sl`.
+func NewFromConfig(config Config) *Slugger {
+	return NewWithOptions(
+		WithSeparator(config.Separator),
+		WithSubstitutions(config.Substitutions),
+		WithEmoji(config.WithEmoji),
+		WithEmojiLocale(config.EmojiLocale),
+		WithMaxLength(config.MaxLength),
+		WithTransliteration(config.Transliterate...),
+		WithLowercase(!config.PreserveCase),
+		WithFallback(config.Fallback),
+		WithKeepChars(config.KeepChars),
+		WithStopwords(config.Stopwords),
+	)
+}
@@ -0,0 +1,152 @@
+package slugger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var testConfig = Config{
+	Separator:     "_",
+	Substitutions: map[string]string{"&": "and", "%": "percent"},
+	WithEmoji:     true,
+	EmojiLocale:   "fr",
+	MaxLength:     40,
+	Transliterate: []string{"ru", "el"},
+	PreserveCase:  true,
+	Fallback:      "untitled",
+	KeepChars:     "+#",
+	Stopwords:     []string{"the", "of"},
+}
+
+var configInputs = []string{
+	"Hello World",
+	"10% & more",
+	"The State of C++ & C# in 2024: 100%",
+	"Привет мир 🌍",
+	"Wôrķšpáçè ~~sèťtïñğš~~",
+	"?!",
+	"",
+}
+
+func TestSlugger_Config(t *testing.T) {
+	slugger := NewFromConfig(testConfig)
+
+	if config := slugger.Config(); !reflect.DeepEqual(config, testConfig) {
+		t.Errorf("expected %+v, got %+v", testConfig, config)
+	}
+
+	// the returned config must not share state with the slugger
+	config := slugger.Config()
+	config.Substitutions["&"] = "und"
+	config.Stopwords[0] = "hello"
+
+	if result := slugger.Slug("Hello & World", ""); result != "Hello_and_World" {
+		t.Errorf("expected %q, got %q", "Hello_and_World", result)
+	}
+}
+
+func TestNewFromConfig_RoundTrip(t *testing.T) {
+	// substitutions changed directly through the field after construction
+	changed := New(map[string]string{"%": "percent"}, false)
+	changed.Substitutions["&"] = "and"
+
+	sluggers := []*Slugger{
+		New(nil, false),
+		New(map[string]string{"€": "euro"}, true),
+		NewFromConfig(testConfig),
+		changed,
+	}
+
+	if result := changed.Slug("10% & more", ""); result != "10-percent-and-more" {
+		t.Errorf("expected %q, got %q", "10-percent-and-more", result)
+	}
+
+	for _, slugger := range sluggers {
+		data, err := json.Marshal(slugger.Config())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var config Config
+		if err := json.Unmarshal(data, &config); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		restored := NewFromConfig(config)
+		for _, input := range configInputs {
+			if expected, result := slugger.Slug(input, ""), restored.Slug(input, ""); result != expected {
+				t.Errorf("Slug(%q) with config %s expected %q, got %q", input, data, expected, result)
+			}
+		}
+	}
+}
+
+func TestConfig_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected Config
+		wantErr  bool
+	}{
+		{
+			name:     "Empty object uses the default separator",
+			data:     `{}`,
+			expected: Config{Separator: "-"},
+		},
+		{
+			name:     "Explicit empty separator",
+			data:     `{"separator": ""}`,
+			expected: Config{Separator: ""},
+		},
+		{
+			name: "All settings",
+			data: `{"separator": "_", "substitutions": {"&": "and"}, "with_emoji": true, "emoji_locale": "fr",
+				"max_length": 60, "transliterate": ["ru"], "preserve_case": true, "fallback": "n-a",
+				"keep_chars": "+", "stopwords": ["the"]}`,
+			expected: Config{
+				Separator:     "_",
+				Substitutions: map[string]string{"&": "and"},
+				WithEmoji:     true,
+				EmojiLocale:   "fr",
+				MaxLength:     60,
+				Transliterate: []string{"ru"},
+				PreserveCase:  true,
+				Fallback:      "n-a",
+				KeepChars:     "+",
+				Stopwords:     []string{"the"},
+			},
+		},
+		{
+			name:    "Invalid JSON",
+			data:    `{"separator": 1}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config Config
+			err := json.Unmarshal([]byte(tt.data), &config)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestConfig_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(New(nil, false).Config())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != `{"separator":"-"}` {
+		t.Errorf("expected %s, got %s", `{"separator":"-"}`, data)
+	}
+}
@@ -0,0 +1,98 @@
+package slugger
+
+import (
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// Deduplicator wraps a Slugger and guarantees that every slug it issues is unique.
+// It is safe for concurrent use.
+type Deduplicator struct {
+	slugger *Slugger
+	seen    map[string]struct{}
+	mu      sync.Mutex
+}
+
+// NewDeduplicator creates a new Deduplicator that generates slugs with the given Slugger.
+func NewDeduplicator(sl *Slugger) *Deduplicator {
+	return &Deduplicator{
+		slugger: sl,
+		seen:    make(map[string]struct{}),
+	}
+}
+
+// Unique generates a slug for `s` like Slug does, appending a counter (`-2`, `-3`, ...) joined
+// with the separator if the slug has already been issued or seeded. The returned slug is
+// recorded so that later calls never return it again.
+//
+// With MaxLength set, the slug is truncated to make room for the counter. If even one rune of
+// the slug does not fit next to the counter, the bare counter is used; only a counter with more
+// digits than MaxLength can exceed it.
+//
+// Without a Fallback on the Slugger, inputs that normalize to an empty slug are issued `""`
+// first and then bare counters (`2`, `3`, ...); set a Fallback when the slugs are used as keys.
+func (d *Deduplicator) Unique(s, separator string) string {
+	if separator == "" {
+		separator = d.slugger.Separator
+	}
+
+	base := d.slugger.Slug(s, separator)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	slug := base
+	for n := 2; d.isSeen(slug); n++ {
+		slug = d.withCounter(base, separator, strconv.Itoa(n))
+	}
+
+	d.seen[slug] = struct{}{}
+
+	return slug
+}
+
+// withCounter joins `base` and `counter` with the separator, truncating `base` so that the
+// result stays within MaxLength.
+func (d *Deduplicator) withCounter(base, separator, counter string) string {
+	if base == "" {
+		return counter
+	}
+
+	suffix := separator + counter
+	maxLength := d.slugger.MaxLength
+	if maxLength <= 0 {
+		return base + suffix
+	}
+
+	budget := maxLength - utf8.RuneCountInString(suffix)
+	if budget < 1 {
+		return counter
+	}
+
+	return string(truncate([]byte(base), separator, budget)) + suffix
+}
+
+// Seed marks the given slugs as already issued, e.g. slugs that already exist in a database.
+func (d *Deduplicator) Seed(slugs ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, slug := range slugs {
+		d.seen[slug] = struct{}{}
+	}
+}
+
+// Reset clears all issued and seeded slugs.
+func (d *Deduplicator) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	clear(d.seen)
+}
+
+func (d *Deduplicator) isSeen(slug string) bool {
+	_, ok := d.seen[slug]
+
+	return ok
+}
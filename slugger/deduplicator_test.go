@@ -0,0 +1,116 @@
+package slugger
+
+import (
+	"testing"
+)
+
+func TestDeduplicator_Unique(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputs    []string
+		separator string
+		seed      []string
+		maxLength int
+		expected  []string
+	}{
+		{
+			name:      "Distinct inputs are not changed",
+			inputs:    []string{"Hello World", "Report 2024"},
+			separator: "-",
+			expected:  []string{"hello-world", "report-2024"},
+		},
+		{
+			name:      "Colliding inputs get a counter",
+			inputs:    []string{"Report 2024", "Report: 2024!", "report 2024"},
+			separator: "-",
+			expected:  []string{"report-2024", "report-2024-2", "report-2024-3"},
+		},
+		{
+			name:      "Counter uses the given separator",
+			inputs:    []string{"Report 2024", "Report: 2024!"},
+			separator: "_",
+			expected:  []string{"report_2024", "report_2024_2"},
+		},
+		{
+			name:      "Counter uses the default separator",
+			inputs:    []string{"Report 2024", "Report: 2024!"},
+			separator: "",
+			expected:  []string{"report-2024", "report-2024-2"},
+		},
+		{
+			name:      "Seeded slugs are not issued",
+			inputs:    []string{"Report 2024", "Hello World"},
+			separator: "-",
+			seed:      []string{"report-2024", "report-2024-2"},
+			expected:  []string{"report-2024-3", "hello-world"},
+		},
+		{
+			name:      "Issued counters are tracked",
+			inputs:    []string{"a", "a", "a 2", "a"},
+			separator: "-",
+			expected:  []string{"a", "a-2", "a-2-2", "a-3"},
+		},
+		{
+			name:      "Counter respects MaxLength",
+			inputs:    []string{"Hello World", "Hello World"},
+			separator: "-",
+			maxLength: 12,
+			expected:  []string{"hello-world", "hello-2"},
+		},
+		{
+			name:      "Counter without room for the slug is used bare",
+			inputs:    []string{"ab", "ab", "ab"},
+			separator: "-",
+			maxLength: 2,
+			expected:  []string{"ab", "2", "3"},
+		},
+		{
+			name:      "Empty slugs get a bare counter",
+			inputs:    []string{"!!!", "???"},
+			separator: "-",
+			expected:  []string{"", "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, false)
+			slugger.MaxLength = tt.maxLength
+			dedup := NewDeduplicator(slugger)
+			dedup.Seed(tt.seed...)
+
+			for i, input := range tt.inputs {
+				if result := dedup.Unique(input, tt.separator); result != tt.expected[i] {
+					t.Errorf("Unique(%q) expected %q, got %q", input, tt.expected[i], result)
+				}
+			}
+		})
+	}
+}
+
+func TestDeduplicator_Unique_Fallback(t *testing.T) {
+	slugger := New(nil, false)
+	slugger.Fallback = "untitled"
+	dedup := NewDeduplicator(slugger)
+
+	for _, expected := range []string{"untitled", "untitled-2", "untitled-3"} {
+		if result := dedup.Unique("!!!", ""); result != expected {
+			t.Errorf("expected %q, got %q", expected, result)
+		}
+	}
+}
+
+func TestDeduplicator_Reset(t *testing.T) {
+	dedup := NewDeduplicator(New(nil, false))
+	dedup.Seed("hello-world")
+
+	if result := dedup.Unique("Hello World", "-"); result != "hello-world-2" {
+		t.Errorf("expected %q, got %q", "hello-world-2", result)
+	}
+
+	dedup.Reset()
+
+	if result := dedup.Unique("Hello World", "-"); result != "hello-world" {
+		t.Errorf("expected %q after Reset, got %q", "hello-world", result)
+	}
+}
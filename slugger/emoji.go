@@ -0,0 +1,208 @@
+package slugger
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/forPelevin/gomoji"
+)
+
+var (
+	emojiLocalesMu sync.RWMutex
+	// emojiLocales maps language codes to emoji names keyed by emoji without variation selectors.
+	// English names come from gomoji and are used for emojis missing from a locale.
+	emojiLocales = map[string]map[string]string{
+		"fr": french,
+	}
+)
+
+var french = map[string]string{
+	"🌍":   "globe-affichant-europe-afrique",
+	"🌎":   "globe-affichant-les-ameriques",
+	"🌏":   "globe-affichant-asie-australie",
+	"😀":   "visage-rieur",
+	"😂":   "visage-riant-aux-larmes",
+	"😊":   "visage-souriant-aux-yeux-rieurs",
+	"😍":   "visage-souriant-aux-yeux-en-forme-de-coeur",
+	"😺":   "chat-qui-sourit",
+	"🐈":   "chat",
+	"🐈‍⬛": "chat-noir",
+	"🐶":   "tete-de-chien",
+	"🦁":   "tete-de-lion",
+	"❤":   "coeur-rouge",
+	"👍":   "pouce-vers-le-haut",
+	"👎":   "pouce-vers-le-bas",
+	"🎉":   "cotillons",
+	"🔥":   "feu",
+	"⭐":   "etoile",
+	"☀":   "soleil",
+	"🌙":   "croissant-de-lune",
+	"🏞":   "parc-national",
+	"🚀":   "fusee",
+	"💻":   "ordinateur-portable",
+	"📱":   "telephone-portable",
+	"☕":   "boisson-chaude",
+	"🍕":   "pizza",
+	"🎂":   "gateau-d-anniversaire",
+	"✅":   "bouton-coche",
+	"⚠":   "avertissement",
+	"🇫🇷":  "drapeau-france",
+}
+
+// RegisterEmojiLocale registers the emoji names of the language `lang` for use with EmojiLocale,
+// extending or overriding the names already registered for it. The names are keyed by emoji,
+// variation selectors are ignored. Emojis without a name in a locale fall back to their English name.
+func RegisterEmojiLocale(lang string, names map[string]string) {
+	emojiLocalesMu.Lock()
+	defer emojiLocalesMu.Unlock()
+
+	lang = strings.ToLower(lang)
+	if emojiLocales[lang] == nil {
+		emojiLocales[lang] = make(map[string]string, len(names))
+	}
+
+	for emoji, name := range names {
+		emojiLocales[lang][stripVariationSelectors(emoji)] = name
+	}
+}
+
+// emojiNames returns the emoji names registered for `lang`, trying the primary language
+// subtag (e.g. "fr" for "fr-CA") if there are none for the full code. It returns nil for English.
+// The caller must hold the read lock.
+func emojiNames(lang string) map[string]string {
+	lang = strings.ToLower(lang)
+	if names, ok := emojiLocales[lang]; ok {
+		return names
+	}
+
+	primary, _, _ := strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+
+	return emojiLocales[primary]
+}
+
+// emojiNode is a node of the trie of all known emoji sequences, keyed by rune without variation selectors.
+type emojiNode struct {
+	children map[rune]*emojiNode
+	emoji    string // emoji ending at this node without variation selectors, empty if none
+	slug     string // English name of the emoji
+}
+
+// emojiTrie returns the trie of all emojis known to gomoji, built on first use.
+//
+// gomoji lists some emojis several times, with and without variation selectors, and the
+// variants do not always share a name. Like gomoji's own lookup, only the entries spelled
+// without variation selectors are used, so that every sequence has exactly one name.
+var emojiTrie = sync.OnceValue(func() *emojiNode {
+	root := &emojiNode{}
+	for _, em := range gomoji.AllEmojis() {
+		emoji := em.Character
+		if emoji == "" || stripVariationSelectors(emoji) != emoji {
+			continue
+		}
+
+		node := root
+		for _, r := range emoji {
+			child, ok := node.children[r]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[rune]*emojiNode)
+				}
+
+				child = &emojiNode{}
+				node.children[r] = child
+			}
+
+			node = child
+		}
+
+		node.emoji, node.slug = emoji, em.Slug
+	}
+
+	return root
+})
+
+// longestEmoji returns the length in bytes of the longest emoji sequence that `s` starts with,
+// including the variation selectors inside or directly after it, and the node where it ends.
+func longestEmoji(root *emojiNode, s string) (int, *emojiNode) {
+	var (
+		length int
+		match  *emojiNode
+	)
+
+	node := root
+	for i, r := range s {
+		if unicode.In(r, unicode.Variation_Selector) {
+			if node == root {
+				break
+			}
+
+			if match != nil && i == length {
+				length = i + utf8.RuneLen(r)
+			}
+
+			continue
+		}
+
+		node = node.children[r]
+		if node == nil {
+			break
+		}
+
+		if node.emoji != "" {
+			length, match = i+utf8.RuneLen(r), node
+		}
+	}
+
+	return length, match
+}
+
+// replaceEmojis replaces the emojis in `s` with their names in the language `lang`, matching the
+// longest known emoji sequence at each position so that ZWJ sequences resolve to a single name.
+func replaceEmojis(s, lang string) string {
+	emojiLocalesMu.RLock()
+	defer emojiLocalesMu.RUnlock()
+
+	names := emojiNames(lang)
+	root := emojiTrie()
+
+	var sb strings.Builder
+	last := 0 // end of the last emoji
+	for i := 0; i < len(s); {
+		if n, match := longestEmoji(root, s[i:]); match != nil {
+			name, ok := names[match.emoji]
+			if !ok {
+				name = match.slug
+			}
+
+			sb.WriteString(s[last:i])
+			sb.WriteString(name)
+			i += n
+			last = i
+
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+
+	if last == 0 {
+		return s
+	}
+
+	sb.WriteString(s[last:])
+
+	return sb.String()
+}
+
+func stripVariationSelectors(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.In(r, unicode.Variation_Selector) {
+			return -1
+		}
+
+		return r
+	}, s)
+}
@@ -0,0 +1,240 @@
+package slugger
+
+import (
+	"testing"
+
+	"github.com/forPelevin/gomoji"
+)
+
+// registerTestEmojiLocale registers an emoji locale that is removed again when the test ends.
+func registerTestEmojiLocale(t *testing.T, lang string, names map[string]string) {
+	t.Helper()
+
+	RegisterEmojiLocale(lang, names)
+	t.Cleanup(func() {
+		emojiLocalesMu.Lock()
+		defer emojiLocalesMu.Unlock()
+
+		delete(emojiLocales, lang)
+	})
+}
+
+func TestSlugger_Slug_EmojiLocale(t *testing.T) {
+	registerTestEmojiLocale(t, "x-test", map[string]string{"🏞️": "parque-nacional"})
+
+	tests := []struct {
+		name     string
+		input    string
+		locale   string
+		expected string
+	}{
+		{
+			name:     "English by default",
+			input:    "Hello 🌍",
+			expected: "hello-globe-showing-europe-africa",
+		},
+		{
+			name:     "French",
+			input:    "Hello 🌍",
+			locale:   "fr",
+			expected: "hello-globe-affichant-europe-afrique",
+		},
+		{
+			name:     "Region subtag falls back to the language",
+			input:    "Bonjour 🌍",
+			locale:   "fr-CA",
+			expected: "bonjour-globe-affichant-europe-afrique",
+		},
+		{
+			name:     "Locale codes are case-insensitive",
+			input:    "🐈‍⬛",
+			locale:   "FR",
+			expected: "chat-noir",
+		},
+		{
+			name:     "Missing names fall back to English",
+			input:    "🌍 🦄",
+			locale:   "fr",
+			expected: "globe-affichant-europe-afrique-unicorn",
+		},
+		{
+			name:     "Unknown locales fall back to English",
+			input:    "Hello 🌍",
+			locale:   "xx",
+			expected: "hello-globe-showing-europe-africa",
+		},
+		{
+			name:     "Registered locale ignores variation selectors",
+			input:    "🏞️ 🏞",
+			locale:   "x-test",
+			expected: "parque-nacional-parque-nacional",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := NewWithOptions(WithEmoji(true), WithEmojiLocale(tt.locale))
+			result := slugger.Slug(tt.input, "-")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRegisterEmojiLocale_Cleanup(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		registerTestEmojiLocale(t, "x-cleanup", map[string]string{"🌍": "terre"})
+
+		if result := NewWithOptions(WithEmoji(true), WithEmojiLocale("x-cleanup")).Slug("🌍", ""); result != "terre" {
+			t.Errorf("expected %q, got %q", "terre", result)
+		}
+	})
+
+	if result := NewWithOptions(WithEmoji(true), WithEmojiLocale("x-cleanup")).Slug("🌍", ""); result != "globe-showing-europe-africa" {
+		t.Errorf("expected the locale to be removed, got %q", result)
+	}
+}
+
+func TestSlugger_Slug_EmojiSequences(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Benchmark string",
+			input:    "a 😺, 🐈‍⬛, and a 🦁 go to 🏞️",
+			expected: "a-grinning-cat-black-cat-and-a-lion-go-to-national-park",
+		},
+		{
+			name:     "ZWJ sequence resolves to a single name",
+			input:    "🐈‍⬛",
+			expected: "black-cat",
+		},
+		{
+			name:     "Variation selector is consumed",
+			input:    "🏞️",
+			expected: "national-park",
+		},
+		{
+			name:     "Adjacent emojis and words are not separated",
+			input:    "Hello🌍🏞️World",
+			expected: "helloglobe-showing-europe-africanational-parkworld",
+		},
+		{
+			name:     "Emoji before a word",
+			input:    "😀test ©2024",
+			expected: "grinning-facetest-copyright2024",
+		},
+		{
+			name:     "ZWJ sequence with variation selectors",
+			input:    "❤️‍🔥 🏳️‍🌈",
+			expected: "heart-on-fire-rainbow-flag",
+		},
+		{
+			name:     "ZWJ sequence with skin tone",
+			input:    "👩🏽‍💻 code",
+			expected: "woman-technologist-medium-skin-tone-code",
+		},
+		{
+			name:     "Family sequence",
+			input:    "👨‍👩‍👧",
+			expected: "family-man-woman-girl",
+		},
+		{
+			name:     "Unknown ZWJ sequence falls back to its components",
+			input:    "🦁\u200d🐈",
+			expected: "lioncat",
+		},
+		{
+			name:     "Stray variation selector and joiner",
+			input:    "a\ufe0f \u200d b",
+			expected: "a-b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, true)
+			result := slugger.Slug(tt.input, "-")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_EmojiVariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Keycap with variation selector",
+			input:    "Top 5️⃣ tips",
+			expected: "top-keycap-5-tips",
+		},
+		{
+			name:     "Keycap without variation selector",
+			input:    "room 2⃣",
+			expected: "room-keycap-2",
+		},
+		{
+			name:     "Keycap with leading variation selector",
+			input:    "️5️⃣",
+			expected: "keycap-5",
+		},
+		{
+			name:     "Headscarf",
+			input:    "🧕",
+			expected: "woman-with-headscarf",
+		},
+		{
+			name:     "Headscarf with skin tone",
+			input:    "🧕🏿",
+			expected: "woman-with-headscarf-dark-skin-tone",
+		},
+		{
+			name:     "Bunny ears",
+			input:    "👯",
+			expected: "people-with-bunny-ears",
+		},
+		{
+			name:     "Bunny ears with gender",
+			input:    "👯‍♂️",
+			expected: "men-with-bunny-ears",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, true)
+			result := slugger.Slug(tt.input, "-")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_EmojiMatchesGomoji(t *testing.T) {
+	withEmoji := New(nil, true)
+	withoutEmoji := New(nil, false)
+
+	for _, em := range gomoji.AllEmojis() {
+		expected := withoutEmoji.Slug(gomoji.ReplaceEmojisWithSlug(em.Character), "-")
+		if expected == "" {
+			// gomoji does not find every emoji it lists, e.g. the ones spelled with variation selectors.
+			continue
+		}
+
+		if result := withEmoji.Slug(em.Character, "-"); result != expected {
+			t.Errorf("%q: expected %q, got %q", em.Character, expected, result)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package slugger
+
+import (
+	"maps"
+	"slices"
+)
+
+// Option configures a Slugger created with NewWithOptions.
+//
+// Options are applied in the order they are given, so a later option overrides an earlier option
+// setting the same value. The order of the options does not change the order in which the input is
+// processed, which is always:
+//  1. emoji replacement (WithEmoji, WithEmojiLocale)
+//  2. transliteration (WithTransliteration)
+//  3. lowercasing (WithLowercase)
+//  4. substitutions (WithSubstitutions)
+//  5. normalization to safe characters and joining with the separator (WithKeepChars, WithSeparator),
+//     leaving out stopwords (WithStopwords)
+//  6. truncation (WithMaxLength)
+//  7. fallback for empty slugs (WithFallback)
+//
+// As emojis are replaced before substitutions, substitution keys cannot match emojis when
+// WithEmoji is enabled.
+type Option func(*Slugger)
+
+// NewWithOptions creates a new Slugger configured with the given options. Without options it
+// behaves like New(nil, false).
+func NewWithOptions(opts ...Option) *Slugger {
+	slugger := &Slugger{
+		Separator: "-",
+	}
+
+	for _, opt := range opts {
+		opt(slugger)
+	}
+
+	slugger.matcher.Store(newSubstitutionMatcher(slugger.Substitutions))
+
+	return slugger
+}
+
+// WithSeparator sets the default separator used when Slug is called with an empty separator.
+func WithSeparator(separator string) Option {
+	return func(slugger *Slugger) {
+		slugger.Separator = separator
+	}
+}
+
+// WithSubstitutions sets the string replacements applied before generating the slug.
+// The map is copied.
+func WithSubstitutions(substitutions map[string]string) Option {
+	return func(slugger *Slugger) {
+		slugger.Substitutions = maps.Clone(substitutions)
+	}
+}
+
+// WithEmoji sets whether emojis are replaced with their names.
+func WithEmoji(withEmoji bool) Option {
+	return func(slugger *Slugger) {
+		slugger.WithEmoji = withEmoji
+	}
+}
+
+// WithEmojiLocale sets the language of the emoji names. It defaults to English.
+func WithEmojiLocale(lang string) Option {
+	return func(slugger *Slugger) {
+		slugger.EmojiLocale = lang
+	}
+}
+
+// WithMaxLength sets the maximum length of the slug in runes. A non-positive value disables truncation.
+func WithMaxLength(maxLength int) Option {
+	return func(slugger *Slugger) {
+		slugger.MaxLength = maxLength
+	}
+}
+
+// WithTransliteration enables romanization of the scripts of the given languages ("ru", "el").
+func WithTransliteration(langs ...string) Option {
+	return func(slugger *Slugger) {
+		slugger.Transliterate = slices.Clone(langs)
+	}
+}
+
+// WithLowercase sets whether the slug is lowercased. It defaults to true.
+func WithLowercase(lowercase bool) Option {
+	return func(slugger *Slugger) {
+		slugger.PreserveCase = !lowercase
+	}
+}
+
+// WithFallback sets the fallback that is slugified and returned when a slug would be empty.
+func WithFallback(fallback string) Option {
+	return func(slugger *Slugger) {
+		slugger.Fallback = fallback
+	}
+}
+
+// WithKeepChars sets extra characters (e.g. "+#") that are kept in the slug instead of being stripped.
+func WithKeepChars(chars string) Option {
+	return func(slugger *Slugger) {
+		slugger.KeepChars = chars
+	}
+}
+
+// WithStopwords sets the words removed from the slug, e.g. EnglishStopwords.
+func WithStopwords(words []string) Option {
+	return func(slugger *Slugger) {
+		slugger.Stopwords = slices.Clone(words)
+	}
+}
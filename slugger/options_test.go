@@ -0,0 +1,101 @@
+package slugger
+
+import (
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		input    string
+		expected string
+	}{
+		{
+			name:     "Defaults",
+			input:    "Hello 🌍 World",
+			expected: "hello-world",
+		},
+		{
+			name:     "WithSeparator",
+			opts:     []Option{WithSeparator("_")},
+			input:    "Hello World",
+			expected: "hello_world",
+		},
+		{
+			name:     "WithSubstitutions",
+			opts:     []Option{WithSubstitutions(map[string]string{"%": "percent", "€": "euro"})},
+			input:    "10% or 5€",
+			expected: "10-percent-or-5-euro",
+		},
+		{
+			name:     "WithEmoji",
+			opts:     []Option{WithEmoji(true)},
+			input:    "Hello 🌍",
+			expected: "hello-globe-showing-europe-africa",
+		},
+		{
+			name:     "WithMaxLength",
+			opts:     []Option{WithMaxLength(16)},
+			input:    "My long title for a blog post",
+			expected: "my-long-title",
+		},
+		{
+			name:     "WithTransliteration",
+			opts:     []Option{WithTransliteration("ru", "el")},
+			input:    "Привет Αθήνα",
+			expected: "privet-athina",
+		},
+		{
+			name:     "WithLowercase",
+			opts:     []Option{WithLowercase(false)},
+			input:    "## My Section",
+			expected: "My-Section",
+		},
+		{
+			name:     "WithFallback",
+			opts:     []Option{WithFallback("untitled")},
+			input:    "?!",
+			expected: "untitled",
+		},
+		{
+			name:     "WithKeepChars",
+			opts:     []Option{WithKeepChars("+#")},
+			input:    "C++ vs C#",
+			expected: "c++-vs-c#",
+		},
+		{
+			name:     "WithStopwords",
+			opts:     []Option{WithStopwords(EnglishStopwords)},
+			input:    "The Quick Brown Fox and the Lazy Dog",
+			expected: "quick-brown-fox-lazy-dog",
+		},
+		{
+			name:     "Later options override earlier ones",
+			opts:     []Option{WithSeparator("_"), WithLowercase(false), WithSeparator("."), WithLowercase(true)},
+			input:    "Hello World",
+			expected: "hello.world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := NewWithOptions(tt.opts...)
+			result := slugger.Slug(tt.input, "")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNewWithOptions_MatchesNew(t *testing.T) {
+	inputs := []string{"Hello World", "Wôrķšpáçè ~~sèťtïñğš~~", "10% or 5€", "a 😺, 🐈‍⬛, and a 🦁 go to 🏞️", ""}
+
+	for _, input := range inputs {
+		if expected, result := New(nil, false).Slug(input, ""), NewWithOptions().Slug(input, ""); result != expected {
+			t.Errorf("Slug(%q) expected %q, got %q", input, expected, result)
+		}
+	}
+}
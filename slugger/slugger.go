@@ -1,74 +1,406 @@
 package slugger
 
 import (
+	"bytes"
 	"maps"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"unicode"
+	"unicode/utf8"
 
-	"github.com/forPelevin/gomoji"
 	"golang.org/x/text/unicode/norm"
 )
 
+// Slugger generates URL-friendly slugs. It is safe for concurrent use as long as substitutions
+// are changed through AddSubstitution, RemoveSubstitution and SetSubstitutions once it is shared.
+// Changing the value of an existing key directly in the Substitutions map is not picked up;
+// use AddSubstitution for that.
 type Slugger struct {
 	Separator     string            // Default character(s) used to separate words in the slug if not explicitly provided
 	Substitutions map[string]string // A map of string replacements to apply before generating the slug
 	WithEmoji     bool              // If true, emojis will be included in a slug-friendly format
+	EmojiLocale   string            // Language code of the emoji names (see RegisterEmojiLocale), English if empty
+	MaxLength     int               // If greater than zero, the slug is truncated to at most this many runes on a word boundary
+	Transliterate []string          // Language codes ("ru", "el") whose scripts are romanized before normalization
+	PreserveCase  bool              // If true, the slug keeps the case of the input instead of being lowercased
+	Fallback      string            // Slugified and returned instead of the slug when it would be empty
+	KeepChars     string            // Extra characters kept in the slug in addition to letters, numbers and "-_."
+	Stopwords     []string          // Words removed from the slug, matched case-insensitively against whole words
+
+	mu      sync.RWMutex                        // guards Substitutions
+	matcher atomic.Pointer[substitutionMatcher] // compiled Substitutions, recompiled when they change
+}
+
+// EnglishStopwords is a small set of common English filler words that can be used as Stopwords.
+var EnglishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "from", "in", "into", "is", "it",
+	"of", "on", "or", "that", "the", "this", "to", "was", "with",
 }
 
+// New creates a new Slugger with the given substitutions and emoji replacement setting.
+// See NewWithOptions for more settings.
 func New(substitutions map[string]string, withEmoji bool) *Slugger {
-	return &Slugger{
-		Separator:     "-",
-		Substitutions: substitutions,
-		WithEmoji:     withEmoji,
+	return NewWithOptions(WithSubstitutions(substitutions), WithEmoji(withEmoji))
+}
+
+// AddSubstitution adds or replaces the substitution of `oldValue` with `newValue`.
+func (slugger *Slugger) AddSubstitution(oldValue, newValue string) {
+	slugger.mu.Lock()
+	defer slugger.mu.Unlock()
+
+	if slugger.Substitutions == nil {
+		slugger.Substitutions = make(map[string]string)
 	}
+
+	slugger.Substitutions[oldValue] = newValue
+	slugger.matcher.Store(newSubstitutionMatcher(slugger.Substitutions))
+}
+
+// RemoveSubstitution removes the substitution of `oldValue`, if any.
+func (slugger *Slugger) RemoveSubstitution(oldValue string) {
+	slugger.mu.Lock()
+	defer slugger.mu.Unlock()
+
+	delete(slugger.Substitutions, oldValue)
+	slugger.matcher.Store(newSubstitutionMatcher(slugger.Substitutions))
+}
+
+// SetSubstitutions replaces all substitutions with a copy of `substitutions`.
+func (slugger *Slugger) SetSubstitutions(substitutions map[string]string) {
+	slugger.mu.Lock()
+	defer slugger.mu.Unlock()
+
+	slugger.Substitutions = maps.Clone(substitutions)
+	slugger.matcher.Store(newSubstitutionMatcher(slugger.Substitutions))
 }
 
 // Slug generates a slugified version of the input string `s` using the provided `separator`.
+// If the slug would be empty, the slugified Fallback is returned instead.
 func (slugger *Slugger) Slug(s, separator string) string {
+	slugger.mu.RLock()
+	defer slugger.mu.RUnlock()
+
 	if separator == "" {
 		separator = slugger.Separator
 	}
 
+	return string(slugger.slugOrFallback(slugger.newBuffers(), s, separator))
+}
+
+// SlugAll generates the slugs of all `inputs` like Slug does and returns them in the same order.
+// It prepares the substitutions and scratch buffers only once for all inputs.
+func (slugger *Slugger) SlugAll(inputs []string, separator string) []string {
+	slugger.mu.RLock()
+	defer slugger.mu.RUnlock()
+
+	if separator == "" {
+		separator = slugger.Separator
+	}
+
+	buf := slugger.newBuffers()
+	slugs := make([]string, len(inputs))
+	for i, s := range inputs {
+		slugs[i] = string(slugger.slugOrFallback(buf, s, separator))
+	}
+
+	return slugs
+}
+
+// buffers holds the state of the slugging pipeline that can be reused between inputs.
+type buffers struct {
+	matcher    *substitutionMatcher
+	normalizer norm.Iter
+	normalized []byte // NFKD normalized input
+	slug       []byte // slug being built
+}
+
+// newBuffers prepares the buffers for slugging. The caller must hold the read lock.
+func (slugger *Slugger) newBuffers() *buffers {
+	return &buffers{matcher: slugger.compiledMatcher()}
+}
+
+// compiledMatcher returns the matcher of the Substitutions, compiling it again if the field
+// was assigned or keys were added to or deleted from the map directly since it was last
+// compiled. The caller must hold the read lock.
+func (slugger *Slugger) compiledMatcher() *substitutionMatcher {
+	if m := slugger.matcher.Load(); m != nil && m.compiledFrom(slugger.Substitutions) {
+		return m
+	}
+
+	m := newSubstitutionMatcher(slugger.Substitutions)
+	slugger.matcher.Store(m)
+
+	return m
+}
+
+// slugOrFallback returns the slug of `s`, or the slug of the Fallback if that is empty.
+// The slug is only valid until `buf` is used again. The caller must hold the read lock.
+func (slugger *Slugger) slugOrFallback(buf *buffers, s, separator string) []byte {
+	if slug := slugger.slug(buf, s, separator, true); len(slug) > 0 || slugger.Fallback == "" {
+		return slug
+	}
+
+	return slugger.slug(buf, slugger.Fallback, separator, false)
+}
+
+// slug runs the slugging pipeline on `s`, removing the Stopwords if `stopwords` is true.
+// The slug is built in `buf` and only valid until `buf` is used again. The caller must hold
+// the read lock.
+func (slugger *Slugger) slug(buf *buffers, s, separator string, stopwords bool) []byte {
 	if slugger.WithEmoji {
-		s = gomoji.ReplaceEmojisWithSlug(s)
+		s = replaceEmojis(s, slugger.EmojiLocale)
 	}
 
-	s = strings.ToLower(s)
+	if len(slugger.Transliterate) > 0 {
+		s = transliterate(s, slugger.Transliterate)
+	}
 
-	sortedKeys := slices.Sorted(maps.Keys(slugger.Substitutions))
-	for _, oldValue := range sortedKeys {
-		newValue := slugger.Substitutions[oldValue]
-		s = strings.ReplaceAll(s, oldValue, " "+newValue)
+	if !slugger.PreserveCase {
+		s = strings.ToLower(s)
 	}
 
-	safe := normalizeToSafeASCII(s)
-	words := strings.Split(safe, " ")
-	var slugBuilder strings.Builder
+	s = buf.matcher.replace(s)
+
+	// normalize to safe characters and join the words with the separator
+	buf.normalized = buf.normalized[:0]
+	for buf.normalizer.InitString(norm.NFKD, s); !buf.normalizer.Done(); {
+		buf.normalized = append(buf.normalized, buf.normalizer.Next()...)
+	}
+
+	buf.slug = buf.slug[:0]
+	pendingSeparator, inWord := false, false
+	wordStart, separatorStart := 0, 0
+
+	for i := 0; i < len(buf.normalized); {
+		r, size := utf8.DecodeRune(buf.normalized[i:])
+		i += size
+
+		if unicode.IsSpace(r) {
+			if inWord && stopwords {
+				slugger.endWord(buf, wordStart, separatorStart, separator)
+				inWord = false
+			}
+
+			pendingSeparator = len(buf.slug) > 0
+
+			continue
+		}
+
+		if !isSafeRune(r, slugger.KeepChars) {
+			continue
+		}
 
-	for i := range words {
-		slugBuilder.WriteString(strings.ToLower(words[i]))
+		if pendingSeparator {
+			separatorStart = len(buf.slug)
+			buf.slug = append(buf.slug, separator...)
+			wordStart = len(buf.slug)
+			pendingSeparator = false
+		}
 
-		if i != len(words)-1 {
-			slugBuilder.WriteString(separator)
+		if !slugger.PreserveCase {
+			r = unicode.ToLower(r)
 		}
+
+		buf.slug = utf8.AppendRune(buf.slug, r)
+		inWord = true
+	}
+
+	if inWord && stopwords {
+		slugger.endWord(buf, wordStart, separatorStart, separator)
 	}
 
-	return slugBuilder.String()
+	buf.slug = collapseSeparators(buf.slug, separator, slugger.KeepChars)
+	buf.slug = truncate(buf.slug, separator, slugger.MaxLength)
+
+	return buf.slug
 }
 
-func normalizeToSafeASCII(s string) string {
-	normalized := norm.NFKD.String(s)
-	// remaining safe ASCII characters
-	const keep string = "-_."
+// endWord removes the stopwords from the word that was just written to the slug at `wordStart`,
+// dropping the separator written before it at `separatorStart` if nothing is left of the word.
+func (slugger *Slugger) endWord(buf *buffers, wordStart, separatorStart int, separator string) {
+	if len(slugger.Stopwords) == 0 {
+		return
+	}
+
+	word := string(buf.slug[wordStart:])
+	kept := slugger.removeStopwords(word, separator)
+
+	if kept == "" {
+		buf.slug = buf.slug[:separatorStart]
+	} else if kept != word {
+		buf.slug = append(buf.slug[:wordStart], kept...)
+	}
+}
+
+// removeStopwords returns `word` without its parts between separators that are stopwords.
+func (slugger *Slugger) removeStopwords(word, separator string) string {
+	if separator == "" {
+		if slugger.isStopword(word) {
+			return ""
+		}
+
+		return word
+	}
+
+	parts := strings.Split(word, separator)
+
+	return strings.Join(slices.DeleteFunc(parts, slugger.isStopword), separator)
+}
+
+// isStopword reports whether `word` case-insensitively matches one of the Stopwords.
+func (slugger *Slugger) isStopword(word string) bool {
+	return word != "" && slices.ContainsFunc(slugger.Stopwords, func(stopword string) bool {
+		return strings.EqualFold(word, stopword)
+	})
+}
+
+// IsValid reports whether `s` is already in canonical slug form for the given `separator`
+// (or the default separator if empty), i.e. whether it could have been returned by Slug:
+// lowercase words unless PreserveCase is set, no leading, trailing or doubled separators, no longer
+// than MaxLength and made only of letters, numbers, the safe characters `-_.` and KeepChars between separators.
+// Leading, trailing and doubled separators are allowed if the separator contains letters, numbers
+// or KeepChars, since Slug does not collapse those.
+func (slugger *Slugger) IsValid(s, separator string) bool {
+	slugger.mu.RLock()
+	defer slugger.mu.RUnlock()
+
+	if separator == "" {
+		separator = slugger.Separator
+	}
+
+	if s == "" {
+		// Slug only returns an empty slug if there is no usable fallback
+		return len(slugger.slug(slugger.newBuffers(), slugger.Fallback, separator, false)) == 0
+	}
+
+	if slugger.MaxLength > 0 && utf8.RuneCountInString(s) > slugger.MaxLength {
+		return false
+	}
+
+	if !norm.NFKD.IsNormalString(s) {
+		return false
+	}
+
+	words := []string{s}
+	if separator != "" {
+		words = strings.Split(s, separator)
+	}
+
+	// only unambiguous separators are collapsed by Slug
+	allowEmpty := separator != "" && ambiguousSeparator(separator, slugger.KeepChars)
+	for _, word := range words {
+		if (word == "" && !allowEmpty) || strings.IndexFunc(word, func(r rune) bool { return !isSafeRune(r, slugger.KeepChars) }) >= 0 {
+			return false
+		}
+
+		// the separator is inserted as is, so only the words are lowercased
+		if !slugger.PreserveCase && strings.ToLower(word) != word {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsValid reports whether `s` is a valid slug for the given `separator` using the rules of
+// a Slugger created with New(nil, false).
+func IsValid(s, separator string) bool {
+	return defaultSlugger.IsValid(s, separator)
+}
+
+var defaultSlugger = New(nil, false)
+
+// truncate shortens the slug `s` in place to at most `maxLength` runes without cutting through
+// a word. It trims back to the last `separator` boundary and strips any trailing separators. A
+// single word longer than `maxLength` is hard-cut. A non-positive `maxLength` disables truncation.
+func truncate(s []byte, separator string, maxLength int) []byte {
+	if maxLength <= 0 || utf8.RuneCount(s) <= maxLength {
+		return s
+	}
+
+	n := 0 // length in bytes of the first maxLength runes
+	for range maxLength {
+		_, size := utf8.DecodeRune(s[n:])
+		n += size
+	}
+
+	cut, rest := s[:n], s[n:]
+	if separator == "" {
+		return cut
+	}
+
+	sep := []byte(separator)
+	if !bytes.HasPrefix(rest, sep) {
+		// the cut landed inside a word or a multi-character separator, so fall back
+		// to the last complete word if there is one
+		if i := partialSuffix(cut, rest, sep); i > 0 {
+			cut = cut[:i]
+		} else if i := bytes.LastIndex(cut, sep); i > 0 {
+			cut = cut[:i]
+		}
+	}
+
+	for bytes.HasSuffix(cut, sep) {
+		cut = cut[:len(cut)-len(sep)]
+	}
+
+	return cut
+}
+
+// remaining safe ASCII characters
+const safeChars string = "-_."
+
+// isSafeRune reports whether `r` may appear in a word of a slug, given the extra characters `keepChars`.
+func isSafeRune(r rune, keepChars string) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || strings.ContainsRune(safeChars, r) || strings.ContainsRune(keepChars, r)
+}
+
+// collapseSeparators removes leading, trailing and doubled separators from `s` in place, which
+// can be left over when the input itself contains the separator (e.g. `a - b` becomes `a-b`
+// instead of `a---b`). Ambiguous separators are left alone, see ambiguousSeparator.
+func collapseSeparators(s []byte, separator, keepChars string) []byte {
+	if separator == "" || ambiguousSeparator(separator, keepChars) {
+		return s
+	}
+
+	// the words are moved to the front, which never overwrites bytes that are still to be read
+	sep := []byte(separator)
+	collapsed := s[:0]
+	for rest := s; len(rest) > 0; {
+		var word []byte
+		word, rest, _ = bytes.Cut(rest, sep)
+		if len(word) == 0 {
+			continue
+		}
+
+		if len(collapsed) > 0 {
+			collapsed = append(collapsed, sep...)
+		}
+
+		collapsed = append(collapsed, word...)
+	}
+
+	return collapsed
+}
+
+// ambiguousSeparator reports whether `separator` contains letters, numbers or characters of
+// `keepChars`, which cannot be told apart from the characters of the words.
+func ambiguousSeparator(separator, keepChars string) bool {
+	return strings.ContainsFunc(separator, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r) || strings.ContainsRune(keepChars, r)
+	})
+}
 
-	var sb strings.Builder
-	for _, r := range normalized {
-		if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsSpace(r) || strings.ContainsRune(keep, r) {
-			sb.WriteRune(r)
+// partialSuffix reports the index at which `cut` ends with the beginning of `separator` whose
+// remainder starts `rest`, or -1 if the cut did not split a separator.
+func partialSuffix(cut, rest, separator []byte) int {
+	for k := 1; k < len(separator); k++ {
+		if bytes.HasSuffix(cut, separator[:k]) && bytes.HasPrefix(rest, separator[k:]) {
+			return len(cut) - k
 		}
 	}
 
-	// Remove extra spaces
-	return strings.Join(strings.Fields(sb.String()), " ")
+	return -1
 }
@@ -1,6 +1,8 @@
 package slugger
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -51,6 +53,12 @@ func TestSlugger_Slug(t *testing.T) {
 			separator: "-",
 			expected:  "workspace-settings",
 		},
+		{
+			name:      "Separators in the input are collapsed",
+			input:     "  --Hello -- World--  ",
+			separator: "-",
+			expected:  "hello-world",
+		},
 	}
 
 	for _, tt := range tests {
@@ -65,6 +73,88 @@ func TestSlugger_Slug(t *testing.T) {
 	}
 }
 
+func TestSlugger_Substitutions(t *testing.T) {
+	slugger := New(map[string]string{"%": "percent"}, false)
+
+	slugger.AddSubstitution("€", "euro")
+	if result := slugger.Slug("10% or 5€", "-"); result != "10-percent-or-5-euro" {
+		t.Errorf("after AddSubstitution expected %q, got %q", "10-percent-or-5-euro", result)
+	}
+
+	slugger.RemoveSubstitution("%")
+	if result := slugger.Slug("10% or 5€", "-"); result != "10-or-5-euro" {
+		t.Errorf("after RemoveSubstitution expected %q, got %q", "10-or-5-euro", result)
+	}
+
+	substitutions := map[string]string{"&": "and"}
+	slugger.SetSubstitutions(substitutions)
+	substitutions["%"] = "percent"
+	if result := slugger.Slug("10% & 5€", "-"); result != "10-and-5" {
+		t.Errorf("after SetSubstitutions expected %q, got %q", "10-and-5", result)
+	}
+
+	var empty Slugger
+	empty.AddSubstitution("&", "and")
+	if result := empty.Slug("this & that", "-"); result != "this-and-that" {
+		t.Errorf("zero value Slugger expected %q, got %q", "this-and-that", result)
+	}
+}
+
+func TestSlugger_Concurrent(t *testing.T) {
+	slugger := New(map[string]string{"&": "and"}, false)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				if result := slugger.Slug("this & that", "-"); result != "this-and-that" {
+					t.Errorf("expected %q, got %q", "this-and-that", result)
+
+					return
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for j := range 100 {
+				key := strconv.Itoa(i*100 + j)
+				slugger.AddSubstitution(key, "n")
+				slugger.RemoveSubstitution(key)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestSlugger_SlugAll(t *testing.T) {
+	slugger := New(map[string]string{"%": "percent", "€": "euro"}, true)
+	slugger.Fallback = "untitled"
+
+	inputs := []string{"Hello World", "10% or 5€", "", "Wôrķšpáçè ~~sèťtïñğš~~", "Hello 🌍", "?!", "Hello World"}
+	result := slugger.SlugAll(inputs, "_")
+
+	if len(result) != len(inputs) {
+		t.Fatalf("expected %d slugs, got %d", len(inputs), len(result))
+	}
+
+	for i, input := range inputs {
+		if expected := slugger.Slug(input, "_"); result[i] != expected {
+			t.Errorf("slug %d of %q expected %q, got %q", i, input, expected, result[i])
+		}
+	}
+
+	if result := slugger.SlugAll(nil, ""); len(result) != 0 {
+		t.Errorf("expected no slugs, got %q", result)
+	}
+}
+
 func BenchmarkSlugger_Slug(b *testing.B) {
 	slugger := &Slugger{
 		Separator: "-",
@@ -106,3 +196,516 @@ func BenchmarkSlugger_Slug_CustomSeparator(b *testing.B) {
 		slugger.Slug("Wôrķšpáçè ~~sèťtïñğš~~", "|")
 	}
 }
+
+func TestSlugger_Slug_MaxLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:      "No limit",
+			input:     "My long title for a blog post",
+			separator: "-",
+			maxLength: 0,
+			expected:  "my-long-title-for-a-blog-post",
+		},
+		{
+			name:      "Shorter than the limit",
+			input:     "Hello World",
+			separator: "-",
+			maxLength: 60,
+			expected:  "hello-world",
+		},
+		{
+			name:      "Exactly the limit",
+			input:     "Hello World",
+			separator: "-",
+			maxLength: 11,
+			expected:  "hello-world",
+		},
+		{
+			name:      "Cut inside a word trims back to the last boundary",
+			input:     "My long title for a blog post",
+			separator: "-",
+			maxLength: 16,
+			expected:  "my-long-title",
+		},
+		{
+			name:      "Cut on a separator strips the trailing separator",
+			input:     "My long title for a blog post",
+			separator: "-",
+			maxLength: 14,
+			expected:  "my-long-title",
+		},
+		{
+			name:      "Single word longer than the limit is hard-cut",
+			input:     "Supercalifragilisticexpialidocious",
+			separator: "-",
+			maxLength: 5,
+			expected:  "super",
+		},
+		{
+			name:      "Multibyte separator counts runes",
+			input:     "one two three",
+			separator: "→",
+			maxLength: 8,
+			expected:  "one→two",
+		},
+		{
+			name:      "Cut inside a multi-character separator",
+			input:     "ab cd",
+			separator: "->",
+			maxLength: 3,
+			expected:  "ab",
+		},
+		{
+			name:      "Truncation happens after normalization",
+			input:     "Wôrķšpáçè ~~sèťtïñğš~~",
+			separator: "-",
+			maxLength: 12,
+			expected:  "workspace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, false)
+			slugger.MaxLength = tt.maxLength
+			result := slugger.Slug(tt.input, tt.separator)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_PreserveCase(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		substitutions map[string]string
+		withEmoji     bool
+		preserveCase  bool
+		expected      string
+	}{
+		{
+			name:     "Lowercased by default",
+			input:    "My Section",
+			expected: "my-section",
+		},
+		{
+			name:         "Case is preserved",
+			input:        "## My Section",
+			preserveCase: true,
+			expected:     "My-Section",
+		},
+		{
+			name:         "Diacritics are stripped without changing case",
+			input:        "Wôrķšpáçè ~~SÈŤTÏÑĞŠ~~",
+			preserveCase: true,
+			expected:     "Workspace-SETTINGS",
+		},
+		{
+			name:          "Mixed case with emoji and substitution",
+			input:         "Tips & Tricks 🌍",
+			substitutions: map[string]string{"&": "And"},
+			withEmoji:     true,
+			preserveCase:  true,
+			expected:      "Tips-And-Tricks-globe-showing-europe-africa",
+		},
+		{
+			name:          "Substitution keys match case-insensitively",
+			input:         "Meet At Noon, aT Home",
+			substitutions: map[string]string{"at": "@"},
+			preserveCase:  true,
+			expected:      "Meet-Noon-Home",
+		},
+		{
+			name:          "Uppercase substitution keys match lowercased input",
+			input:         "Meet At Noon",
+			substitutions: map[string]string{"AT": "around"},
+			expected:      "meet-around-noon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(tt.substitutions, tt.withEmoji)
+			slugger.PreserveCase = tt.preserveCase
+			result := slugger.Slug(tt.input, "-")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_Fallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		fallback  string
+		expected  string
+	}{
+		{
+			name:     "Empty by default",
+			input:    "🌍",
+			expected: "",
+		},
+		{
+			name:     "Emoji without emoji replacement",
+			input:    "🌍",
+			fallback: "untitled",
+			expected: "untitled",
+		},
+		{
+			name:     "Only punctuation",
+			input:    "?!&%",
+			fallback: "n-a",
+			expected: "n-a",
+		},
+		{
+			name:     "Empty input",
+			input:    "",
+			fallback: "untitled",
+			expected: "untitled",
+		},
+		{
+			name:     "Not used for non-empty slugs",
+			input:    "Hello World",
+			fallback: "untitled",
+			expected: "hello-world",
+		},
+		{
+			name:      "Fallback is slugified",
+			input:     "~~~",
+			separator: "_",
+			fallback:  "No Title <script>",
+			expected:  "no_title_script",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, false)
+			slugger.Fallback = tt.fallback
+			result := slugger.Slug(tt.input, tt.separator)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		expected  bool
+	}{
+		{name: "Canonical slug", input: "hello-world", separator: "-", expected: true},
+		{name: "Default separator", input: "hello-world", separator: "", expected: true},
+		{name: "Custom separator", input: "hello_world", separator: "_", expected: true},
+		{name: "Safe characters inside words", input: "v1.2_beta-3", separator: "/", expected: true},
+		{name: "Single word", input: "hello", separator: "-", expected: true},
+		{name: "Empty slug", input: "", separator: "-", expected: true},
+		{name: "Uppercase", input: "Hello-world", separator: "-", expected: false},
+		{name: "Leading separator", input: "-hello", separator: "-", expected: false},
+		{name: "Trailing separator", input: "hello-", separator: "-", expected: false},
+		{name: "Doubled separator", input: "hello--world", separator: "-", expected: false},
+		{name: "Whitespace", input: "hello world", separator: "-", expected: false},
+		{name: "Unsafe characters", input: "hello-w@rld", separator: "-", expected: false},
+		{name: "Diacritics", input: "café", separator: "-", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsValid(tt.input, tt.separator); result != tt.expected {
+				t.Errorf("IsValid(%q, %q) expected %v, got %v", tt.input, tt.separator, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		slugger  *Slugger
+		input    string
+		expected bool
+	}{
+		{name: "Uppercase with PreserveCase", slugger: &Slugger{Separator: "-", PreserveCase: true}, input: "My-Section", expected: true},
+		{name: "Longer than MaxLength", slugger: &Slugger{Separator: "-", MaxLength: 5}, input: "hello-world", expected: false},
+		{name: "Empty with Fallback", slugger: &Slugger{Separator: "-", Fallback: "untitled"}, input: "", expected: false},
+		{name: "Empty with unusable Fallback", slugger: &Slugger{Separator: "-", Fallback: "!!!"}, input: "", expected: true},
+		{name: "Doubled kept separator", slugger: &Slugger{Separator: "+", KeepChars: "+"}, input: "c+++c", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.slugger.IsValid(tt.input, ""); result != tt.expected {
+				t.Errorf("IsValid(%q) expected %v, got %v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_IsValid_AgreesWithSlug(t *testing.T) {
+	inputs := []string{
+		"Hello World",
+		"  --Hello -- World--  ",
+		"a - b _ c . d",
+		"Wôrķšpáçè ~~sèťtïñğš~~",
+		"ℌello İstanbul ﬁle",
+		"10% or 5€ & more",
+		"C++ + C# - snake__case",
+		"Привет мир Αθήνα",
+		"a 😺, 🐈‍⬛, and a 🦁 go to 🏞️",
+		"?!",
+		"",
+	}
+	sluggers := []*Slugger{
+		New(nil, false),
+		New(map[string]string{"&": "and", "%": "-percent-"}, true),
+		{Separator: "_", PreserveCase: true, MaxLength: 10},
+		{Separator: "-", Fallback: "untitled", Transliterate: []string{"ru", "el"}},
+		{Separator: "-", KeepChars: "+-#"},
+	}
+
+	for _, slugger := range sluggers {
+		for _, separator := range []string{"", "-", "_", ".", "/", "->", "+", "X"} {
+			for _, input := range inputs {
+				slug := slugger.Slug(input, separator)
+				if !slugger.IsValid(slug, separator) {
+					t.Errorf("IsValid(%q, %q) is false for the slug of %q", slug, separator, input)
+				}
+			}
+		}
+	}
+}
+
+var benchmarkTags = []string{
+	"Go", "Rust & WebAssembly", "Wôrķšpáçè ~~sèťtïñğš~~", "Machine Learning", "10% Off Sale",
+	"Cloud-Native Apps", "Über Café", "Distributed  Systems", "  Leading and trailing  ", "Data Science 101",
+}
+
+func BenchmarkSlugger_SlugAll(b *testing.B) {
+	slugger := New(map[string]string{"&": "and", "%": "percent"}, false)
+	b.ReportAllocs()
+
+	for b.Loop() {
+		slugger.SlugAll(benchmarkTags, "")
+	}
+}
+
+func BenchmarkSlugger_SlugAll_Loop(b *testing.B) {
+	slugger := New(map[string]string{"&": "and", "%": "percent"}, false)
+	b.ReportAllocs()
+
+	for b.Loop() {
+		slugs := make([]string, len(benchmarkTags))
+		for i, tag := range benchmarkTags {
+			slugs[i] = slugger.Slug(tag, "")
+		}
+	}
+}
+
+func TestSlugger_Slug_CollapseSeparators(t *testing.T) {
+	// Slug used to keep separators from the input, e.g. "a - b" gave "a---b" and
+	// "snake__case" with "_" gave "snake__case". They are collapsed so that every slug
+	// passes IsValid.
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		keepChars string
+		expected  string
+	}{
+		{
+			name:      "Separator surrounded by spaces",
+			input:     "a - b",
+			separator: "-",
+			expected:  "a-b",
+		},
+		{
+			name:      "Doubled separator",
+			input:     "snake__case",
+			separator: "_",
+			expected:  "snake_case",
+		},
+		{
+			name:      "Leading and trailing separators",
+			input:     "--Hello World--",
+			separator: "-",
+			expected:  "hello-world",
+		},
+		{
+			name:      "Other safe characters are kept",
+			input:     "a - b",
+			separator: "_",
+			expected:  "a_-_b",
+		},
+		{
+			name:      "Kept characters equal to the separator are not collapsed",
+			input:     "C++ + C",
+			separator: "+",
+			keepChars: "+",
+			expected:  "c+++++c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, false)
+			slugger.KeepChars = tt.keepChars
+			result := slugger.Slug(tt.input, tt.separator)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+
+			if !slugger.IsValid(result, tt.separator) {
+				t.Errorf("expected %q to be valid", result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_KeepChars(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		separator     string
+		keepChars     string
+		substitutions map[string]string
+		expected      string
+	}{
+		{
+			name:     "Stripped by default",
+			input:    "C++ vs C#",
+			expected: "c-vs-c",
+		},
+		{
+			name:      "Kept characters pass through",
+			input:     "C++ vs C#",
+			keepChars: "+#",
+			expected:  "c++-vs-c#",
+		},
+		{
+			name:      "Kept characters are not collapsed into separators",
+			input:     "A + B, .NET",
+			keepChars: "+",
+			expected:  "a-+-b-.net",
+		},
+		{
+			name:          "Substitutions still apply to kept characters",
+			input:         "C++ vs C#",
+			keepChars:     "+#",
+			substitutions: map[string]string{"+": "plus"},
+			expected:      "c-plus-plus-vs-c#",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(tt.substitutions, false)
+			slugger.KeepChars = tt.keepChars
+			result := slugger.Slug(tt.input, tt.separator)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_Stopwords(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		stopwords []string
+		fallback  string
+		expected  string
+	}{
+		{
+			name:      "English stopwords",
+			input:     "The Quick Brown Fox and the Lazy Dog",
+			stopwords: EnglishStopwords,
+			expected:  "quick-brown-fox-lazy-dog",
+		},
+		{
+			name:      "Substrings of words are kept",
+			input:     "The theater of Thebes",
+			stopwords: []string{"the", "of"},
+			expected:  "theater-thebes",
+		},
+		{
+			name:      "Matched case-insensitively",
+			input:     "Over THE Rainbow",
+			stopwords: []string{"The"},
+			expected:  "over-rainbow",
+		},
+		{
+			name:      "Words joined by the separator are split",
+			input:     "state-of-the-art design",
+			stopwords: EnglishStopwords,
+			expected:  "state-art-design",
+		},
+		{
+			name:      "Custom separator",
+			input:     "The Lord of the Rings",
+			separator: "_",
+			stopwords: EnglishStopwords,
+			expected:  "lord_rings",
+		},
+		{
+			name:      "Only stopwords",
+			input:     "To be or not to be",
+			stopwords: []string{"to", "be", "or", "not"},
+			expected:  "",
+		},
+		{
+			name:      "Only stopwords with fallback",
+			input:     "To be or not to be",
+			stopwords: []string{"to", "be", "or", "not"},
+			fallback:  "untitled",
+			expected:  "untitled",
+		},
+		{
+			name:      "Stopwords are kept in the fallback",
+			input:     "The and of",
+			stopwords: EnglishStopwords,
+			fallback:  "n-a",
+			expected:  "n-a",
+		},
+		{
+			name:      "Fallback that is a stopword",
+			input:     "The and of",
+			stopwords: EnglishStopwords,
+			fallback:  "A",
+			expected:  "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, false)
+			slugger.Stopwords = tt.stopwords
+			slugger.Fallback = tt.fallback
+			result := slugger.Slug(tt.input, tt.separator)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
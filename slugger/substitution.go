@@ -0,0 +1,272 @@
+package slugger
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// substitutionMatcher applies the substitutions one key at a time in sorted key order, so that
+// the value inserted for a key can be matched by the keys that follow it. To avoid scanning
+// the input for every key, the case-folded keys are stored in a trie that finds all keys
+// occurring in the input in a single pass, and only those keys are replaced. A later key
+// can only newly occur where it overlaps an inserted value, so only the text around the
+// inserted values is scanned again.
+type substitutionMatcher struct {
+	root         trieNode
+	keys         []string          // keys in sorted order, without the empty key
+	replacements []string          // replacements of the keys, in the same order
+	anchors      []anchor          // what to search for to find the candidate matches of each key
+	maxKeyRunes  int               // length in runes of the longest key
+	source       map[string]string // substitutions the matcher was compiled from
+	size         int               // number of substitutions in source when the matcher was compiled
+}
+
+// anchor is a part of a key that can be found with strings.Index: either the longest run of
+// runes of the key that are only equal to themselves under case folding, or, if there is none,
+// the case variants of the first rune.
+type anchor struct {
+	variants []string // what to search for, any of which is a candidate
+	offset   int      // number of runes of the key before the anchor
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	keys     []int // indexes in keys of the keys ending at this node, which only differ in case
+}
+
+// newSubstitutionMatcher compiles the given substitutions. Empty keys are ignored.
+func newSubstitutionMatcher(substitutions map[string]string) *substitutionMatcher {
+	m := &substitutionMatcher{
+		source: substitutions,
+		size:   len(substitutions),
+	}
+
+	for _, oldValue := range slices.Sorted(maps.Keys(substitutions)) {
+		if oldValue == "" {
+			continue
+		}
+
+		node := &m.root
+		for _, r := range oldValue {
+			r = foldRune(r)
+
+			child, ok := node.children[r]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[rune]*trieNode)
+				}
+
+				child = &trieNode{}
+				node.children[r] = child
+			}
+
+			node = child
+		}
+
+		node.keys = append(node.keys, len(m.keys))
+		m.keys = append(m.keys, oldValue)
+		m.replacements = append(m.replacements, " "+substitutions[oldValue])
+		m.anchors = append(m.anchors, newAnchor(oldValue))
+		m.maxKeyRunes = max(m.maxKeyRunes, utf8.RuneCountInString(oldValue))
+	}
+
+	return m
+}
+
+// compiledFrom reports whether the matcher was compiled from the map `substitutions` and the
+// map still has the same number of entries. Changes that keep the number of entries, such as
+// changing the value of a key, are not detected.
+func (m *substitutionMatcher) compiledFrom(substitutions map[string]string) bool {
+	return len(substitutions) == m.size &&
+		reflect.ValueOf(substitutions).UnsafePointer() == reflect.ValueOf(m.source).UnsafePointer()
+}
+
+// replace returns a copy of `s` with all occurrences of each key replaced by its value, matching
+// keys case-insensitively. Keys are replaced one after another in sorted order, so with `&` and
+// `&&` both registered `&` is replaced first, and a value can be replaced again by a later key.
+func (m *substitutionMatcher) replace(s string) string {
+	if len(m.keys) == 0 {
+		return s
+	}
+
+	present := make([]bool, len(m.keys))
+	m.markPresent(s, 0, len(s), present)
+
+	var inserted []int
+	for i, oldValue := range m.keys {
+		if !present[i] {
+			continue
+		}
+
+		newValue := m.replacements[i]
+		s, inserted = replaceAllFold(s, oldValue, newValue, m.anchors[i], inserted[:0])
+		for _, start := range inserted {
+			m.markPresent(s, start, start+len(newValue), present)
+		}
+	}
+
+	return s
+}
+
+// markPresent sets present[i] for every key i that occurs in `s` and overlaps s[from:to].
+// It may also set it for keys that occur close to s[from:to] without overlapping it.
+func (m *substitutionMatcher) markPresent(s string, from, to int, present []bool) {
+	for n := 1; n < m.maxKeyRunes && from > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(s[:from])
+		from -= size
+	}
+
+	for i := from; i < to; {
+		node := &m.root
+		for _, r := range s[i:] {
+			node = node.children[foldRune(r)]
+			if node == nil {
+				break
+			}
+
+			for _, key := range node.keys {
+				present[key] = true
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+}
+
+// newAnchor returns the anchor of the non-empty key `oldValue`.
+func newAnchor(oldValue string) anchor {
+	var (
+		best             anchor
+		bestLen          int // length in bytes of the best run
+		runStart, offset int // start of the current run in bytes and in runes
+	)
+	for i, r := range oldValue {
+		if unicode.SimpleFold(r) != r {
+			runStart, offset = i+utf8.RuneLen(r), offset+1
+
+			continue
+		}
+
+		if end := i + utf8.RuneLen(r); end-runStart > bestLen {
+			best, bestLen = anchor{variants: []string{oldValue[runStart:end]}, offset: offset}, end-runStart
+		}
+	}
+
+	if bestLen > 0 {
+		return best
+	}
+
+	first, _ := utf8.DecodeRuneInString(oldValue)
+	variants := []string{string(first)}
+	for r := unicode.SimpleFold(first); r != first; r = unicode.SimpleFold(r) {
+		variants = append(variants, string(r))
+	}
+
+	return anchor{variants: variants}
+}
+
+// replaceAllFold returns a copy of `s` with all non-overlapping occurrences of `oldValue`
+// replaced by `newValue`, matching `oldValue` case-insensitively. `newValue` is inserted
+// verbatim, and its offsets in the result are appended to `inserted`. `a` is the anchor
+// of `oldValue`.
+func replaceAllFold(s, oldValue, newValue string, a anchor, inserted []int) (string, []int) {
+	// next[j] is the offset of the next occurrence of a.variants[j] at or after i,
+	// len(s) if there is none, or -1 if it still needs to be searched for
+	next := make([]int, len(a.variants))
+	for j := range next {
+		next[j] = -1
+	}
+
+	var sb strings.Builder
+	last := 0 // end of the last match
+	for i := 0; i < len(s); {
+		candidate := len(s)
+		for j, variant := range a.variants {
+			if next[j] < i {
+				next[j] = len(s)
+				if k := strings.Index(s[i:], variant); k >= 0 {
+					next[j] = i + k
+				}
+			}
+
+			candidate = min(candidate, next[j])
+		}
+
+		if candidate == len(s) {
+			break
+		}
+
+		start := candidate
+		for n := 0; n < a.offset && start > i; n++ {
+			_, size := utf8.DecodeLastRuneInString(s[i:start])
+			start -= size
+		}
+
+		n := prefixFold(s[start:], oldValue)
+		if n == 0 || utf8.RuneCountInString(s[start:candidate]) != a.offset {
+			i = candidate + 1
+
+			continue
+		}
+
+		sb.WriteString(s[last:start])
+		inserted = append(inserted, sb.Len())
+		sb.WriteString(newValue)
+		i = start + n
+		last = i
+	}
+
+	if last == 0 {
+		return s, inserted
+	}
+
+	sb.WriteString(s[last:])
+
+	return sb.String(), inserted
+}
+
+// prefixFold returns the length in bytes of the prefix of `s` that case-insensitively matches
+// `prefix`, or 0 if `s` does not start with `prefix`.
+func prefixFold(s, prefix string) int {
+	n := 0
+	for _, want := range prefix {
+		got, size := utf8.DecodeRuneInString(s[n:])
+		if size == 0 || !equalFoldRune(got, want) {
+			return 0
+		}
+
+		n += size
+	}
+
+	return n
+}
+
+// equalFoldRune reports whether `a` and `b` are equal under simple Unicode case folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+
+	return false
+}
+
+// foldRune maps `r` to the smallest rune that is equal to it under simple Unicode case folding.
+func foldRune(r rune) rune {
+	folded := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		folded = min(folded, f)
+	}
+
+	return folded
+}
@@ -0,0 +1,289 @@
+package slugger
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSubstitutionMatcher_Replace(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		substitutions map[string]string
+		expected      string
+	}{
+		{
+			name:          "No substitutions",
+			input:         "this & that",
+			substitutions: nil,
+			expected:      "this & that",
+		},
+		{
+			name:          "Single key",
+			input:         "this & that",
+			substitutions: map[string]string{"&": "and"},
+			expected:      "this  and that",
+		},
+		{
+			name:          "Keys are replaced in sorted order",
+			input:         "a && b & c",
+			substitutions: map[string]string{"&": "and", "&&": "double"},
+			expected:      "a  and and b  and c",
+		},
+		{
+			name:          "Shorter key sorted first",
+			input:         "abc abd",
+			substitutions: map[string]string{"a": "1", "abc": "3"},
+			expected:      " 1bc  1bd",
+		},
+		{
+			name:          "Case-insensitive",
+			input:         "AT at aT",
+			substitutions: map[string]string{"At": "@"},
+			expected:      " @  @  @",
+		},
+		{
+			name:          "Replacements are substituted again by later keys",
+			input:         "a",
+			substitutions: map[string]string{"a": "b", "b": "c"},
+			expected:      "  c",
+		},
+		{
+			name:          "Replacements are not substituted again by earlier keys",
+			input:         "b",
+			substitutions: map[string]string{"a": "x", "b": "a"},
+			expected:      " a",
+		},
+		{
+			name:          "Keys that only differ in case",
+			input:         "AT at",
+			substitutions: map[string]string{"AT": "x at", "at": "y"},
+			expected:      " x  y  x  y",
+		},
+		{
+			name:          "Multibyte keys",
+			input:         "5€ or 5 €",
+			substitutions: map[string]string{"€": "euro"},
+			expected:      "5 euro or 5  euro",
+		},
+		{
+			name:          "Empty keys are ignored",
+			input:         "abc",
+			substitutions: map[string]string{"": "x"},
+			expected:      "abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newSubstitutionMatcher(tt.substitutions).replace(tt.input)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugger_Slug_OverlappingSubstitutions(t *testing.T) {
+	slugger := New(map[string]string{"&": "and", "&&": "double"}, false)
+
+	if result := slugger.Slug("A && B & C", "-"); result != "a-and-and-b-and-c" {
+		t.Errorf("expected %q, got %q", "a-and-and-b-and-c", result)
+	}
+}
+
+func TestSubstitutionMatcher_MatchesReplaceEachKey(t *testing.T) {
+	substitutionSets := []map[string]string{
+		{"&": "and", "&&": "double", "and": "&"},
+		{"a": "b", "b": "c", "c": "a b"},
+		{"the": "", "he": "she", "she": "they"},
+		{"ß": "ss", "SS": "double s", "€": "euro"},
+		{"AT": "x at", "at": "y", "t": "T"},
+		{"b": "c", "cd": "z", "zz": "!"},
+		{"a1b": "x", "1": "one", "k1": "kelvin", "x2x2": "y"},
+	}
+	inputs := []string{
+		"", "a", "a && b & c", "the hero and she", "Straße STRASSE 5€", "AT at aT tat", "abcabc", "abd ab d zcd",
+		"A1B a1b1 K1 \u212a1 11", "x2x2x2 X2X2",
+	}
+
+	for _, substitutions := range substitutionSets {
+		matcher := newSubstitutionMatcher(substitutions)
+		for _, input := range inputs {
+			if expected, result := replaceEachKey(input, substitutions), matcher.replace(input); result != expected {
+				t.Errorf("replace(%q) with %v expected %q, got %q", input, substitutions, expected, result)
+			}
+		}
+	}
+}
+
+func TestSlugger_Slug_SubstitutionsWithoutConstructor(t *testing.T) {
+	slugger := &Slugger{Separator: "-", Substitutions: map[string]string{"&": "and"}}
+
+	if result := slugger.Slug("this & that", ""); result != "this-and-that" {
+		t.Errorf("expected %q, got %q", "this-and-that", result)
+	}
+}
+
+func TestSlugger_Slug_SubstitutionsChangedDirectly(t *testing.T) {
+	slugger := New(nil, false)
+
+	slugger.Substitutions = map[string]string{"&": "and"}
+	if result := slugger.Slug("this & that", ""); result != "this-and-that" {
+		t.Errorf("after assigning the field expected %q, got %q", "this-and-that", result)
+	}
+
+	slugger.Substitutions["%"] = "percent"
+	if result := slugger.Slug("10% & more", ""); result != "10-percent-and-more" {
+		t.Errorf("after adding to the map expected %q, got %q", "10-percent-and-more", result)
+	}
+
+	slugger.AddSubstitution("&", "plus")
+	if result := slugger.Slug("this & that", ""); result != "this-plus-that" {
+		t.Errorf("after changing a value expected %q, got %q", "this-plus-that", result)
+	}
+
+	delete(slugger.Substitutions, "&")
+	if result := slugger.Slug("this & that", ""); result != "this-that" {
+		t.Errorf("after deleting from the map expected %q, got %q", "this-that", result)
+	}
+
+	slugger.Substitutions = nil
+	if result := slugger.Slug("10% & more", ""); result != "10-more" {
+		t.Errorf("after clearing the field expected %q, got %q", "10-more", result)
+	}
+}
+
+func TestSubstitutionMatcher_MatchesPreviousImplementation(t *testing.T) {
+	substitutions, benchmarks := benchmarkSubstitutions()
+	substitutionSets := []map[string]string{
+		substitutions,
+		{"&": "and", "&&": "double", "and": "&"},
+		{"a": "b", "b": "c", "c": "a b"},
+		{"the": "", "he": "she", "she": "they"},
+	}
+	inputs := []string{"", "A && B & C", "The hero and she", "abcabc"}
+	for _, benchmark := range benchmarks {
+		inputs = append(inputs, benchmark.input)
+	}
+
+	for _, substitutions := range substitutionSets {
+		matcher := newSubstitutionMatcher(substitutions)
+		for _, input := range inputs {
+			expected := replaceBeforeMatcher(input, substitutions)
+			if result := matcher.replace(strings.ToLower(input)); result != expected {
+				t.Errorf("replace(%q) with %d substitutions expected %q, got %q", input, len(substitutions), expected, result)
+			}
+		}
+	}
+}
+
+// replaceEachKey replaces the keys one after another in sorted order, scanning the input once
+// for every key. It is the reference for the matcher.
+func replaceEachKey(s string, substitutions map[string]string) string {
+	for _, oldValue := range slices.Sorted(maps.Keys(substitutions)) {
+		if oldValue == "" {
+			continue
+		}
+
+		var sb strings.Builder
+		for i := 0; i < len(s); {
+			if n := prefixFold(s[i:], oldValue); n > 0 {
+				sb.WriteString(" " + substitutions[oldValue])
+				i += n
+
+				continue
+			}
+
+			_, size := utf8.DecodeRuneInString(s[i:])
+			sb.WriteString(s[i : i+size])
+			i += size
+		}
+
+		s = sb.String()
+	}
+
+	return s
+}
+
+// replaceBeforeMatcher is the implementation used before the matcher, which lowercases the
+// input and matches keys case-sensitively. It is the baseline for the benchmarks.
+func replaceBeforeMatcher(s string, substitutions map[string]string) string {
+	s = strings.ToLower(s)
+
+	sortedKeys := slices.Sorted(maps.Keys(substitutions))
+	for _, oldValue := range sortedKeys {
+		newValue := substitutions[oldValue]
+		s = strings.ReplaceAll(s, oldValue, " "+newValue)
+	}
+
+	return s
+}
+
+type substitutionBenchmark struct {
+	name  string
+	input string
+}
+
+// benchmarkSubstitutions returns 400 substitutions, with an input that contains two of
+// the keys and one that contains all of them.
+func benchmarkSubstitutions() (map[string]string, []substitutionBenchmark) {
+	substitutions := make(map[string]string, 400)
+	for i := range 400 {
+		substitutions[fmt.Sprintf("sym%03d", i)] = fmt.Sprintf("symbol %d", i)
+	}
+
+	return substitutions, []substitutionBenchmark{
+		{
+			name:  "sparse",
+			input: strings.Repeat("the price is 10 sym042 or 12 sym399 & more text without symbols ", 20),
+		},
+		{
+			name:  "dense",
+			input: strings.Join(slices.Sorted(maps.Keys(substitutions)), " "),
+		},
+	}
+}
+
+func BenchmarkSubstitutionMatcher_Replace(b *testing.B) {
+	substitutions, benchmarks := benchmarkSubstitutions()
+	matcher := newSubstitutionMatcher(substitutions)
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				matcher.replace(bm.input)
+			}
+		})
+	}
+}
+
+func BenchmarkSubstitutions_ReplaceBeforeMatcher(b *testing.B) {
+	substitutions, benchmarks := benchmarkSubstitutions()
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				replaceBeforeMatcher(bm.input, substitutions)
+			}
+		})
+	}
+}
+
+func BenchmarkSlugger_Slug_ManySubstitutions(b *testing.B) {
+	substitutions, benchmarks := benchmarkSubstitutions()
+	slugger := New(substitutions, false)
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				slugger.Slug(bm.input, "")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package slugger
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliteration romanizes the characters of a single script.
+type transliteration struct {
+	script *unicode.RangeTable // characters of this script that are not in the table are dropped
+	table  map[rune]string     // lowercase characters and their romanization
+}
+
+// transliterations maps the supported language codes to their romanization tables.
+var transliterations = map[string]transliteration{
+	"ru": {script: unicode.Cyrillic, table: russian},
+	"el": {script: unicode.Greek, table: greek},
+}
+
+var russian = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+var greek = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+// transliterate romanizes the characters of `s` that belong to the scripts of the given languages.
+// Accented characters are looked up by their base letter and unknown characters of an enabled
+// script are dropped. Unknown language codes are ignored.
+func transliterate(s string, langs []string) string {
+	var enabled []transliteration
+	for _, lang := range langs {
+		if t, ok := transliterations[strings.ToLower(lang)]; ok {
+			enabled = append(enabled, t)
+		}
+	}
+
+	if len(enabled) == 0 {
+		return s
+	}
+
+	var sb strings.Builder
+	for _, r := range s {
+		t, ok := findTransliteration(enabled, r)
+		if !ok {
+			sb.WriteRune(r)
+
+			continue
+		}
+
+		latin, ok := t.romanize(r)
+		if !ok {
+			continue
+		}
+
+		if unicode.IsUpper(r) && latin != "" {
+			latin = strings.ToUpper(latin[:1]) + latin[1:]
+		}
+
+		sb.WriteString(latin)
+	}
+
+	return sb.String()
+}
+
+func findTransliteration(enabled []transliteration, r rune) (transliteration, bool) {
+	for _, t := range enabled {
+		if unicode.Is(t.script, r) {
+			return t, true
+		}
+	}
+
+	return transliteration{}, false
+}
+
+// romanize returns the romanization of `r`, falling back to its base letter without diacritics.
+func (t transliteration) romanize(r rune) (string, bool) {
+	r = unicode.ToLower(r)
+	if latin, ok := t.table[r]; ok {
+		return latin, true
+	}
+
+	decomposed := []rune(norm.NFD.String(string(r)))
+	if len(decomposed) > 1 {
+		if latin, ok := t.table[decomposed[0]]; ok {
+			return latin, true
+		}
+	}
+
+	return "", false
+}
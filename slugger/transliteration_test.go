@@ -0,0 +1,88 @@
+package slugger
+
+import (
+	"testing"
+)
+
+func TestSlugger_Slug_Transliterate(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		transliterate []string
+		withEmoji     bool
+		expected      string
+	}{
+		{
+			name:     "Disabled keeps the script",
+			input:    "Привет мир",
+			expected: "привет-мир",
+		},
+		{
+			name:          "Russian",
+			input:         "Привет мир",
+			transliterate: []string{"ru"},
+			expected:      "privet-mir",
+		},
+		{
+			name:          "Russian multi-letter romanizations",
+			input:         "Щука и Ёжик, объявление",
+			transliterate: []string{"ru"},
+			expected:      "shchuka-i-ezhik-obyavlenie",
+		},
+		{
+			name:          "Greek with accents",
+			input:         "Αθήνα",
+			transliterate: []string{"el"},
+			expected:      "athina",
+		},
+		{
+			name:          "Multiple languages",
+			input:         "Москва и Αθήνα",
+			transliterate: []string{"ru", "EL"},
+			expected:      "moskva-i-athina",
+		},
+		{
+			name:          "Only enabled scripts are transliterated",
+			input:         "Москва и Αθήνα",
+			transliterate: []string{"el"},
+			expected:      "москва-и-athina",
+		},
+		{
+			name:          "Unknown characters of an enabled script are dropped",
+			input:         "Київ",
+			transliterate: []string{"ru"},
+			expected:      "kiv",
+		},
+		{
+			name:          "Unknown languages are ignored",
+			input:         "Привет",
+			transliterate: []string{"xx"},
+			expected:      "привет",
+		},
+		{
+			name:          "Latin input is unchanged",
+			input:         "Wôrķšpáçè ~~sèťtïñğš~~",
+			transliterate: []string{"ru", "el"},
+			expected:      "workspace-settings",
+		},
+		{
+			name:          "Runs after emoji replacement",
+			input:         "Привет 🌍",
+			transliterate: []string{"ru"},
+			withEmoji:     true,
+			expected:      "privet-globe-showing-europe-africa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(nil, tt.withEmoji)
+			slugger.Transliterate = tt.transliterate
+			result := slugger.Slug(tt.input, "-")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package slugger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrWriterClosed is returned when writing to a closed SlugWriter.
+var ErrWriterClosed = errors.New("slugger: write to closed SlugWriter")
+
+// slugWriter writes the slug of every line written to it to an underlying writer.
+type slugWriter struct {
+	w         io.Writer
+	slugger   *Slugger
+	separator string
+	pending   []byte   // incomplete line, possibly ending in an incomplete rune
+	out       []byte   // slugged lines waiting to be written
+	buf       *buffers // scratch buffers reused between lines
+	err       error    // sticky error of the underlying writer or ErrWriterClosed
+}
+
+// NewSlugWriter returns a writer that buffers the bytes written to it and writes the slug of each
+// line, followed by a newline, to `w` once the line is complete. Lines are slugged with `sl` and
+// `separator` like Slug does. Close writes the slug of a trailing line without a newline, but does
+// not close `w`.
+func NewSlugWriter(w io.Writer, sl *Slugger, separator string) io.WriteCloser {
+	return &slugWriter{
+		w:         w,
+		slugger:   sl,
+		separator: separator,
+		buf:       &buffers{},
+	}
+}
+
+// Write slugs every complete line in `p` and writes it to the underlying writer.
+// Incomplete lines are buffered until the rest is written or the writer is closed. All of `p` is
+// consumed even if writing to the underlying writer fails; the error is then returned by every
+// later call.
+func (sw *slugWriter) Write(p []byte) (int, error) {
+	if sw.err != nil {
+		return 0, sw.err
+	}
+
+	sw.pending = append(sw.pending, p...)
+	i := bytes.LastIndexByte(sw.pending, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	sw.slugLines(sw.pending[:i+1])
+
+	// keep the incomplete tail; a newline byte never occurs inside a multibyte rune
+	sw.pending = sw.pending[:copy(sw.pending, sw.pending[i+1:])]
+
+	// p has been consumed even if writing its slugs fails
+	if err := sw.flush(); err != nil {
+		return len(p), err
+	}
+
+	return len(p), nil
+}
+
+// Close writes the slug of the buffered trailing line, if any. It does not close the underlying writer.
+func (sw *slugWriter) Close() error {
+	if sw.err != nil {
+		if errors.Is(sw.err, ErrWriterClosed) {
+			return nil
+		}
+
+		return sw.err
+	}
+
+	sw.slugLines(sw.pending)
+	sw.pending = sw.pending[:0]
+
+	if err := sw.flush(); err != nil {
+		return err
+	}
+
+	sw.err = ErrWriterClosed
+
+	return nil
+}
+
+// slugLines replaces the pending output with the slugs of the lines in `data`, keeping their
+// newlines. The lines are converted to a string once and sliced, and each slug is built in the
+// scratch buffers and appended to the output, so lines are not copied into separate strings.
+func (sw *slugWriter) slugLines(data []byte) {
+	sl := sw.slugger
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	separator := sw.separator
+	if separator == "" {
+		separator = sl.Separator
+	}
+
+	sw.buf.matcher = sl.compiledMatcher()
+	sw.out = sw.out[:0]
+	lines := string(data)
+	for len(lines) > 0 {
+		line, rest, found := strings.Cut(lines, "\n")
+		sw.out = append(sw.out, sl.slugOrFallback(sw.buf, line, separator)...)
+		if found {
+			sw.out = append(sw.out, '\n')
+		}
+
+		lines = rest
+	}
+}
+
+func (sw *slugWriter) flush() error {
+	if len(sw.out) == 0 {
+		return nil
+	}
+
+	if _, err := sw.w.Write(sw.out); err != nil {
+		sw.err = err
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package slugger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSlugWriter(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator string
+		expected  string
+	}{
+		{
+			name:     "Lines",
+			input:    "Hello World\nWôrķšpáçè ~~sèťtïñğš~~\n",
+			expected: "hello-world\nworkspace-settings\n",
+		},
+		{
+			name:     "Trailing line without newline",
+			input:    "Hello World\n10% or 5€",
+			expected: "hello-world\n10-percent-or-5-euro",
+		},
+		{
+			name:     "Empty lines",
+			input:    "\nHello World\n\n",
+			expected: "\nhello-world\n\n",
+		},
+		{
+			name:     "Windows line endings",
+			input:    "Hello World\r\nfoo bar\r\n",
+			expected: "hello-world\nfoo-bar\n",
+		},
+		{
+			name:      "Custom separator",
+			input:     "Hello World\nfoo bar",
+			separator: "_",
+			expected:  "hello_world\nfoo_bar",
+		},
+		{
+			name:     "Empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugger := New(map[string]string{"%": "percent", "€": "euro"}, false)
+
+			// write everything at once
+			var out bytes.Buffer
+			sw := NewSlugWriter(&out, slugger, tt.separator)
+			if _, err := io.Copy(sw, strings.NewReader(tt.input)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := sw.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+
+			// write one byte at a time, splitting multibyte runes
+			out.Reset()
+			sw = NewSlugWriter(&out, slugger, tt.separator)
+			for i := range len(tt.input) {
+				if _, err := sw.Write([]byte{tt.input[i]}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if err := sw.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != tt.expected {
+				t.Errorf("byte by byte expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestSlugWriter_BuffersIncompleteLines(t *testing.T) {
+	var out bytes.Buffer
+	sw := NewSlugWriter(&out, New(nil, false), "")
+
+	if _, err := sw.Write([]byte("Hello Wo")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be written for an incomplete line, got %q", out.String())
+	}
+
+	if _, err := sw.Write([]byte("rld\nÜber")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.String() != "hello-world\n" {
+		t.Errorf("expected %q, got %q", "hello-world\n", out.String())
+	}
+}
+
+func TestSlugWriter_Closed(t *testing.T) {
+	var out bytes.Buffer
+	sw := NewSlugWriter(&out, New(nil, false), "")
+
+	if err := sw.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := sw.Write([]byte("Hello World\n")); !errors.Is(err, ErrWriterClosed) {
+		t.Errorf("expected %v, got %v", ErrWriterClosed, err)
+	}
+
+	if err := sw.Close(); err != nil {
+		t.Errorf("expected closing twice to succeed, got %v", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestSlugWriter_WriteError(t *testing.T) {
+	sw := NewSlugWriter(failingWriter{}, New(nil, false), "")
+
+	p := []byte("Hello World\nfoo")
+	n, err := sw.Write(p)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if n != len(p) {
+		t.Errorf("expected all %d bytes to be consumed, got %d", len(p), n)
+	}
+
+	if _, err := sw.Write([]byte("Hello World\n")); err == nil {
+		t.Error("expected the error to be sticky")
+	}
+
+	if err := sw.Close(); err == nil {
+		t.Error("expected Close to return the error")
+	}
+}
+
+func BenchmarkSlugWriter(b *testing.B) {
+	input := []byte(strings.Repeat("Wôrķšpáçè ~~sèťtïñğš~~\nhello world\n", 50))
+	sw := NewSlugWriter(io.Discard, New(nil, false), "")
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := sw.Write(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}